@@ -0,0 +1,21 @@
+package adapter
+
+import "context"
+
+// Notification is a single payload delivered over a LISTEN/NOTIFY channel.
+type Notification struct {
+	Channel string
+	Payload string
+}
+
+// Listener is implemented by adapters that can support PostgreSQL
+// LISTEN/NOTIFY (e.g. pgx, which can dedicate a connection to a listen for
+// as long as it's needed). Adapters built on short-lived pooled
+// connections that can't support it (e.g. plain database/sql ones) simply
+// don't implement this interface: callers type-assert a ConnPool to
+// Listener and fall back to polling alone when the assertion fails.
+type Listener interface {
+	// Listen subscribes to channel and streams notifications until ctx is
+	// done, at which point the returned channel is closed.
+	Listen(ctx context.Context, channel string) (<-chan Notification, error)
+}
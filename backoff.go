@@ -0,0 +1,22 @@
+package gue
+
+import "time"
+
+// Backoff calculates the duration to wait before the next retry attempt.
+// It receives the number of times the job has failed so far (including
+// the failure that just happened), the error returned by the handler, and
+// how long the handler ran before failing, so that strategies can adapt to
+// both the failure pattern and the job's typical runtime.
+type Backoff interface {
+	Duration(errorCount int, lastErr error, lastDuration time.Duration) time.Duration
+}
+
+// BackoffFunc adapts a plain errorCount-based function to the Backoff
+// interface, for strategies that don't need the error or last run
+// duration.
+type BackoffFunc func(errorCount int) time.Duration
+
+// Duration implements Backoff.
+func (f BackoffFunc) Duration(errorCount int, _ error, _ time.Duration) time.Duration {
+	return f(errorCount)
+}
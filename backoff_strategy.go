@@ -0,0 +1,147 @@
+package gue
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ConstantBackoff retries after the same fixed delay every time,
+// regardless of how many times the job has failed.
+type ConstantBackoff time.Duration
+
+// Duration implements Backoff.
+func (b ConstantBackoff) Duration(int, error, time.Duration) time.Duration {
+	return time.Duration(b)
+}
+
+// LinearBackoff retries after errorCount * Step, capped at Max once Max is
+// set to a positive value.
+type LinearBackoff struct {
+	Step time.Duration
+	Max  time.Duration
+}
+
+// Duration implements Backoff.
+func (b LinearBackoff) Duration(errorCount int, _ error, _ time.Duration) time.Duration {
+	d := time.Duration(errorCount) * b.Step
+	if b.Max > 0 && d > b.Max {
+		return b.Max
+	}
+	return d
+}
+
+// JitterExponentialBackoff retries after Base * 2^errorCount, capped at Max
+// once Max is set to a positive value, with up to +/-Jitter fraction of
+// random noise applied so that jobs which failed together don't all retry
+// in lockstep.
+type JitterExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// Duration implements Backoff.
+func (b JitterExponentialBackoff) Duration(errorCount int, _ error, _ time.Duration) time.Duration {
+	d := expBackoffDuration(b.Base, errorCount, b.Max)
+	return applyJitter(d, b.Jitter)
+}
+
+// expBackoffDuration returns base * 2^errorCount, clamped to max once max
+// is positive. errorCount is capped before shifting, and base is checked
+// against the largest value that can be shifted that far, so a very large
+// errorCount saturates at math.MaxInt64 instead of silently overflowing
+// time.Duration (int64) into a tiny or negative delay the way a raw
+// base*(1<<errorCount) would.
+func expBackoffDuration(base time.Duration, errorCount int, max time.Duration) time.Duration {
+	const maxShift = 62 // base<<62 is already far beyond any useful backoff
+
+	shift := errorCount
+	if shift < 0 {
+		shift = 0
+	} else if shift > maxShift {
+		shift = maxShift
+	}
+
+	d := time.Duration(math.MaxInt64)
+	if base <= time.Duration(math.MaxInt64)>>uint(shift) {
+		d = base << uint(shift)
+	}
+	if max > 0 && d > max {
+		d = max
+	}
+	return d
+}
+
+// applyJitter adds up to +/-jitter fraction of random noise to d, saturating
+// at math.MaxInt64 rather than overflowing. A jitter of 0 (or less) returns
+// d unchanged.
+func applyJitter(d time.Duration, jitter float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+
+	delta := float64(d) * jitter
+	jittered := float64(d) + (rand.Float64()*2-1)*delta
+	if jittered >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(jittered)
+}
+
+// EWMABackoff adapts its retry delay to a moving average of how long this
+// job type's handler has recently taken before failing: on every retry it
+// updates ewma = alpha*lastDuration + (1-alpha)*ewma, then schedules the
+// retry at max(MinDelay, ewma * 2^errorCount) with +/-Jitter fraction of
+// random noise, so that retries spread out instead of all landing at once
+// after an outage. A single EWMABackoff instance is meant to be shared
+// across every job of one type, registered via WithClientBackoffFor.
+type EWMABackoff struct {
+	// Alpha is the smoothing factor applied to each new sample. Defaults
+	// to 0.2 when left zero.
+	Alpha float64
+	// MinDelay is the smallest delay ever returned.
+	MinDelay time.Duration
+	// Jitter is the fraction of random noise applied to the computed
+	// delay, e.g. 0.2 for +/-20%. Defaults to 0.2 when left zero.
+	Jitter float64
+
+	mu   sync.Mutex
+	ewma time.Duration
+}
+
+// NewEWMABackoff creates an EWMABackoff with the given minimum delay and
+// the default alpha/jitter of 0.2.
+func NewEWMABackoff(minDelay time.Duration) *EWMABackoff {
+	return &EWMABackoff{Alpha: 0.2, MinDelay: minDelay, Jitter: 0.2}
+}
+
+// Duration implements Backoff.
+func (b *EWMABackoff) Duration(errorCount int, _ error, lastDuration time.Duration) time.Duration {
+	alpha := b.Alpha
+	if alpha <= 0 {
+		alpha = 0.2
+	}
+
+	b.mu.Lock()
+	switch {
+	case b.ewma == 0:
+		b.ewma = lastDuration
+	case lastDuration > 0:
+		b.ewma = time.Duration(alpha*float64(lastDuration) + (1-alpha)*float64(b.ewma))
+	}
+	ewma := b.ewma
+	b.mu.Unlock()
+
+	d := expBackoffDuration(ewma, errorCount, 0)
+	if d < b.MinDelay {
+		d = b.MinDelay
+	}
+
+	jitter := b.Jitter
+	if jitter <= 0 {
+		jitter = 0.2
+	}
+	return applyJitter(d, jitter)
+}
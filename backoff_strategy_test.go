@@ -0,0 +1,44 @@
+package gue
+
+import (
+	"testing"
+	"time"
+)
+
+// TestJitterExponentialBackoffDoesNotOverflow checks that a large
+// errorCount caps out at Max (or stays positive when Max is unset) instead
+// of silently wrapping time.Duration's int64 into something tiny or
+// negative.
+func TestJitterExponentialBackoffDoesNotOverflow(t *testing.T) {
+	b := JitterExponentialBackoff{Base: time.Second, Max: time.Hour}
+	for _, errorCount := range []int{10, 62, 63, 64, 1000} {
+		d := b.Duration(errorCount, nil, 0)
+		if d <= 0 {
+			t.Errorf("errorCount=%d: Duration = %v, want a positive duration", errorCount, d)
+		}
+		if d > time.Hour+time.Hour/10 { // allow for jitter, default 0 here means none anyway
+			t.Errorf("errorCount=%d: Duration = %v, want capped near Max (%v)", errorCount, d, b.Max)
+		}
+	}
+}
+
+func TestJitterExponentialBackoffNoMaxStaysPositive(t *testing.T) {
+	b := JitterExponentialBackoff{Base: time.Second}
+	for _, errorCount := range []int{62, 63, 64, 1000} {
+		if d := b.Duration(errorCount, nil, 0); d <= 0 {
+			t.Errorf("errorCount=%d: Duration = %v, want a positive duration", errorCount, d)
+		}
+	}
+}
+
+func TestEWMABackoffDoesNotOverflow(t *testing.T) {
+	b := NewEWMABackoff(time.Second)
+	// Seed the moving average with a sample so ewma is nonzero.
+	b.Duration(1, nil, 5*time.Second)
+
+	for _, errorCount := range []int{62, 63, 64, 1000} {
+		if d := b.Duration(errorCount, nil, 0); d <= 0 {
+			t.Errorf("errorCount=%d: Duration = %v, want a positive duration", errorCount, d)
+		}
+	}
+}
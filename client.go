@@ -6,6 +6,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/vgarvardt/gue/v2/adapter"
@@ -16,13 +18,20 @@ import (
 // specified.
 var ErrMissingType = errors.New("job type must be specified")
 
+// ErrJobAlreadyExists is returned when you attempt to enqueue a job whose
+// Key matches another job that is still pending or running in the same
+// queue. See WithUniqueKey.
+var ErrJobAlreadyExists = errors.New("job with this unique key already exists")
+
 // Client is a Gue client that can add jobs to the queue and remove jobs from
 // the queue.
 type Client struct {
-	pool    adapter.ConnPool
-	logger  adapter.Logger
-	id      string
-	backoff Backoff
+	pool          adapter.ConnPool
+	logger        adapter.Logger
+	id            string
+	backoff       Backoff
+	backoffs      map[string]Backoff
+	notifyEnabled bool
 }
 
 // NewClient creates a new Client that uses the pgx pool.
@@ -30,7 +39,7 @@ func NewClient(pool adapter.ConnPool, options ...ClientOption) *Client {
 	instance := Client{
 		pool:    pool,
 		logger:  adapter.NoOpLogger{},
-		backoff: exponential.Default,
+		backoff: BackoffFunc(exponential.Default),
 	}
 
 	for _, option := range options {
@@ -46,6 +55,15 @@ func NewClient(pool adapter.ConnPool, options ...ClientOption) *Client {
 	return &instance
 }
 
+// backoffFor returns the Backoff registered for jobType via
+// WithClientBackoffFor, falling back to the client's default Backoff.
+func (c *Client) backoffFor(jobType string) Backoff {
+	if b, ok := c.backoffs[jobType]; ok {
+		return b
+	}
+	return c.backoff
+}
+
 // Enqueue adds a job to the queue.
 func (c *Client) Enqueue(ctx context.Context, j *Job) error {
 	return c.execEnqueue(ctx, j, c.pool)
@@ -61,6 +79,112 @@ func (c *Client) EnqueueTx(ctx context.Context, j *Job, tx adapter.Tx) error {
 	return c.execEnqueue(ctx, j, tx)
 }
 
+// EnqueueBatch adds every job in jobs to the queue with a single
+// multi-row INSERT, populating ID on each one. This is significantly
+// cheaper than calling Enqueue in a loop for fan-out workloads that
+// produce many jobs at once. The insert is all-or-nothing: if any job is
+// rejected (e.g. a duplicate unique Key), none of them are enqueued. If
+// WithClientNotify is set, this also issues one additional pg_notify round
+// trip per distinct queue represented in jobs, not one per job.
+func (c *Client) EnqueueBatch(ctx context.Context, jobs []*Job) error {
+	return c.execEnqueueBatch(ctx, jobs, c.pool)
+}
+
+// EnqueueBatchTx is EnqueueBatch scoped to a transaction; see EnqueueTx.
+func (c *Client) EnqueueBatchTx(ctx context.Context, jobs []*Job, tx adapter.Tx) error {
+	return c.execEnqueueBatch(ctx, jobs, tx)
+}
+
+func (c *Client) execEnqueueBatch(ctx context.Context, jobs []*Job, q adapter.Queryable) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	valueStrings := make([]string, 0, len(jobs))
+	args := make([]interface{}, 0, len(jobs)*8)
+	for _, j := range jobs {
+		if j.Type == "" {
+			return ErrMissingType
+		}
+
+		if j.RunAt.IsZero() {
+			j.RunAt = now
+		}
+
+		if len(j.Args) == 0 {
+			j.Args = []byte(`[]`)
+		}
+
+		var uniqueKey, uniqueUntil interface{}
+		if j.Key != "" {
+			uniqueKey = j.Key
+			if !j.UniqueUntil.IsZero() {
+				uniqueUntil = j.UniqueUntil
+			}
+
+			if err := reapExpiredUniqueKey(ctx, q, j.Queue, j.Key, now); err != nil {
+				return err
+			}
+		}
+
+		base := len(args)
+		valueStrings = append(valueStrings, fmt.Sprintf(
+			"($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+6, base+7, base+8,
+		))
+		args = append(args, j.Queue, j.Priority, j.RunAt, j.Type, j.Args, now, uniqueKey, uniqueUntil)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO gue_jobs
+(queue, priority, run_at, job_type, args, created_at, updated_at, unique_key, unique_until)
+VALUES
+%s
+RETURNING job_id`, strings.Join(valueStrings, ", "))
+
+	// Postgres preserves the order of a multi-row VALUES INSERT's RETURNING
+	// output, so job_id N corresponds to jobs[N].
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrJobAlreadyExists
+		}
+		return err
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		if err := rows.Scan(&jobs[i].ID); err != nil {
+			return err
+		}
+		i++
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if c.notifyEnabled {
+		// At most one pg_notify per distinct queue in the batch: the
+		// payload is only ever used as a wakeup signal (see notify), and a
+		// woken worker drains every ready job in its queue once it wakes,
+		// so notifying per-row would turn this single round-trip insert
+		// into N+1 round trips for no benefit.
+		notified := make(map[string]bool, len(jobs))
+		for _, j := range jobs {
+			if notified[j.Queue] {
+				continue
+			}
+			notified[j.Queue] = true
+			c.notify(ctx, q, j.Queue, j.ID)
+		}
+	}
+
+	return nil
+}
+
 func (c *Client) execEnqueue(ctx context.Context, j *Job, q adapter.Queryable) error {
 	if j.Type == "" {
 		return ErrMissingType
@@ -77,11 +201,28 @@ func (c *Client) execEnqueue(ctx context.Context, j *Job, q adapter.Queryable) e
 		j.Args = []byte(`[]`)
 	}
 
+	var uniqueKey, uniqueUntil interface{}
+	if j.Key != "" {
+		uniqueKey = j.Key
+
+		if !j.UniqueUntil.IsZero() {
+			uniqueUntil = j.UniqueUntil
+		}
+
+		if err := reapExpiredUniqueKey(ctx, q, j.Queue, j.Key, now); err != nil {
+			return err
+		}
+	}
+
 	err := q.QueryRow(ctx, `INSERT INTO gue_jobs
-(queue, priority, run_at, job_type, args, created_at, updated_at)
+(queue, priority, run_at, job_type, args, created_at, updated_at, unique_key, unique_until)
 VALUES
-($1, $2, $3, $4, $5, $6, $6) RETURNING job_id
-`, j.Queue, j.Priority, j.RunAt, j.Type, j.Args, now).Scan(&j.ID)
+($1, $2, $3, $4, $5, $6, $6, $7, $8) RETURNING job_id
+`, j.Queue, j.Priority, j.RunAt, j.Type, j.Args, now, uniqueKey, uniqueUntil).Scan(&j.ID)
+
+	if isUniqueViolation(err) {
+		err = ErrJobAlreadyExists
+	}
 
 	c.logger.Debug(
 		"Tried to enqueue a job",
@@ -90,9 +231,61 @@ VALUES
 		adapter.F("id", j.ID),
 	)
 
+	if err == nil && c.notifyEnabled {
+		c.notify(ctx, q, j.Queue, j.ID)
+	}
+
+	return err
+}
+
+// notify tells any worker LISTENing on the job's queue that a new job just
+// arrived, so it can wake up immediately instead of waiting out its poll
+// interval. It is strictly a latency optimization, off by default (see
+// WithClientNotify): failing to notify (e.g. the driver doesn't support
+// LISTEN/NOTIFY) never fails the enqueue, since polling always picks the
+// job up eventually.
+func (c *Client) notify(ctx context.Context, q adapter.Queryable, queue string, jobID int64) {
+	if _, err := q.Exec(ctx, "SELECT pg_notify($1, $2)", notifyChannel(queue), strconv.FormatInt(jobID, 10)); err != nil {
+		c.logger.Debug("Failed to notify listeners of new job", adapter.Err(err), adapter.F("queue", queue))
+	}
+}
+
+// reapExpiredUniqueKey lazily deletes the job occupying key in queue once
+// its WithUniqueUntil has expired, so a caller enqueueing a new job with
+// the same key isn't stuck behind it forever. Both Enqueue/EnqueueTx and
+// EnqueueBatch/EnqueueBatchTx call this for every job that sets a Key
+// (whether or not the new job sets WithUniqueUntil itself, since it is the
+// existing row's expiry that counts), so single and batch enqueue share the
+// same dedup semantics. This deletes the prior row unconditionally, including one
+// that is still sitting unworked in gue_jobs: an expired UniqueUntil
+// discards that job rather than merely releasing its key, so only use
+// WithUniqueUntil where that is acceptable.
+func reapExpiredUniqueKey(ctx context.Context, q adapter.Queryable, queue, key string, now time.Time) error {
+	_, err := q.Exec(ctx, `
+DELETE FROM gue_jobs WHERE queue = $1 AND unique_key = $2 AND unique_until <= $3
+`, queue, key, now)
 	return err
 }
 
+// sqlStater is implemented by driver errors (e.g. pgconn.PgError) that can
+// report a SQLSTATE code.
+type sqlStater interface {
+	SQLState() string
+}
+
+// postgres error code for unique_violation, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const pgErrCodeUniqueViolation = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pgErr sqlStater
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == pgErrCodeUniqueViolation
+	}
+
+	return false
+}
+
 // LockJob attempts to retrieve a Job from the database in the specified queue.
 // If a job is found, it will be locked on the transactional level, so other workers
 // will be skipping it. If no job is found, nil will be returned instead of an error.
@@ -109,12 +302,13 @@ func (c *Client) LockJob(ctx context.Context, queue string, maxErrorCount int) (
 		return nil, err
 	}
 
-	j := Job{pool: c.pool, tx: tx, backoff: c.backoff}
+	j := Job{pool: c.pool, tx: tx, backoff: c.backoff, clientID: c.id, maxErrorCount: maxErrorCount}
 
 	query := `
 SELECT job_id, queue, priority, run_at, job_type, args, error_count
 FROM gue_jobs
 WHERE run_at <= $1 AND queue = $2
+AND NOT EXISTS (SELECT 1 FROM gue_queues q WHERE q.name = gue_jobs.queue AND q.paused_at IS NOT NULL)
 %s
 ORDER BY priority ASC
 LIMIT 1 FOR UPDATE SKIP LOCKED`
@@ -137,6 +331,8 @@ LIMIT 1 FOR UPDATE SKIP LOCKED`
 		&j.ErrorCount,
 	)
 	if err == nil {
+		j.backoff = c.backoffFor(j.Type)
+		j.lockedAt = time.Now()
 		return &j, nil
 	}
 
@@ -154,12 +350,13 @@ func (c *Client) LockJobMinError(ctx context.Context, queue string, minErrorCoun
 		return nil, err
 	}
 
-	j := Job{pool: c.pool, tx: tx, backoff: c.backoff}
+	j := Job{pool: c.pool, tx: tx, backoff: c.backoff, clientID: c.id}
 
 	query := `
 SELECT job_id, queue, priority, run_at, job_type, args, error_count
 FROM gue_jobs
 WHERE error_count >= $1
+AND NOT EXISTS (SELECT 1 FROM gue_queues q WHERE q.name = gue_jobs.queue AND q.paused_at IS NOT NULL)
 %s
 ORDER BY priority ASC
 LIMIT 1 FOR UPDATE SKIP LOCKED`
@@ -182,6 +379,8 @@ LIMIT 1 FOR UPDATE SKIP LOCKED`
 		&j.ErrorCount,
 	)
 	if err == nil {
+		j.backoff = c.backoffFor(j.Type)
+		j.lockedAt = time.Now()
 		return &j, nil
 	}
 
@@ -0,0 +1,137 @@
+package gue
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// TestEnqueueBatchPreservesRETURNINGOrder checks, against a fake pool, that
+// execEnqueueBatch assigns the i-th job_id of the RETURNING output to
+// jobs[i]; that mapping relies on Postgres returning job_id for a
+// multi-row VALUES INSERT in the same order the rows were listed.
+func TestEnqueueBatchPreservesRETURNINGOrder(t *testing.T) {
+	jobs := []*Job{
+		NewJob("q1", "typeA", nil),
+		NewJob("q2", "typeB", nil, WithUniqueKey("k2")),
+		NewJob("q3", "typeC", nil),
+	}
+
+	var gotQuery string
+	var gotArgs []interface{}
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryFn: func(ctx context.Context, query string, args ...interface{}) (adapter.Rows, error) {
+			gotQuery = query
+			gotArgs = args
+			return &fakeRows{
+				n: len(jobs),
+				scanFn: func(row int, dest ...interface{}) error {
+					*(dest[0].(*int64)) = int64(100 + row)
+					return nil
+				},
+			}, nil
+		},
+	}}
+
+	c := NewClient(pool)
+	if err := c.EnqueueBatch(context.Background(), jobs); err != nil {
+		t.Fatalf("EnqueueBatch returned error: %v", err)
+	}
+
+	for i, j := range jobs {
+		want := int64(100 + i)
+		if j.ID != want {
+			t.Errorf("jobs[%d].ID = %d, want %d (RETURNING order not preserved)", i, j.ID, want)
+		}
+	}
+
+	// Every row contributes 8 bind values (one placeholder is reused for
+	// both created_at and updated_at), and one VALUES tuple per job.
+	if len(gotArgs) != 8*len(jobs) {
+		t.Errorf("got %d bind args, want %d", len(gotArgs), 8*len(jobs))
+	}
+	if got := strings.Count(gotQuery, "), ("); got != len(jobs)-1 {
+		t.Errorf("query has %d VALUES tuple separators, want %d", got, len(jobs)-1)
+	}
+}
+
+// TestEnqueueBatchNotifiesOncePerDistinctQueue checks that a WithClientNotify
+// batch enqueue issues at most one pg_notify per distinct queue represented
+// in the batch, not one per job.
+func TestEnqueueBatchNotifiesOncePerDistinctQueue(t *testing.T) {
+	jobs := []*Job{
+		NewJob("q1", "typeA", nil),
+		NewJob("q1", "typeA", nil),
+		NewJob("q2", "typeB", nil),
+	}
+
+	var notifyCalls int
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryFn: func(ctx context.Context, query string, args ...interface{}) (adapter.Rows, error) {
+			return &fakeRows{
+				n: len(jobs),
+				scanFn: func(row int, dest ...interface{}) error {
+					*(dest[0].(*int64)) = int64(row)
+					return nil
+				},
+			}, nil
+		},
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "pg_notify") {
+				notifyCalls++
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	c := NewClient(pool, WithClientNotify(true))
+	if err := c.EnqueueBatch(context.Background(), jobs); err != nil {
+		t.Fatalf("EnqueueBatch returned error: %v", err)
+	}
+
+	if notifyCalls != 2 {
+		t.Errorf("got %d pg_notify calls, want 1 per distinct queue (2)", notifyCalls)
+	}
+}
+
+// TestEnqueueBatchSkipsNotifyByDefault checks that EnqueueBatch never calls
+// pg_notify unless WithClientNotify(true) was set.
+func TestEnqueueBatchSkipsNotifyByDefault(t *testing.T) {
+	jobs := []*Job{NewJob("q1", "typeA", nil)}
+
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryFn: func(ctx context.Context, query string, args ...interface{}) (adapter.Rows, error) {
+			return &fakeRows{
+				n:      len(jobs),
+				scanFn: func(row int, dest ...interface{}) error { return nil },
+			}, nil
+		},
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "pg_notify") {
+				t.Fatal("pg_notify should not be called without WithClientNotify")
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	c := NewClient(pool)
+	if err := c.EnqueueBatch(context.Background(), jobs); err != nil {
+		t.Fatalf("EnqueueBatch returned error: %v", err)
+	}
+}
+
+func TestEnqueueBatchEmpty(t *testing.T) {
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryFn: func(context.Context, string, ...interface{}) (adapter.Rows, error) {
+			t.Fatal("Query should not be called for an empty batch")
+			return nil, nil
+		},
+	}}
+
+	c := NewClient(pool)
+	if err := c.EnqueueBatch(context.Background(), nil); err != nil {
+		t.Fatalf("EnqueueBatch(nil) returned error: %v", err)
+	}
+}
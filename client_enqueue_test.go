@@ -0,0 +1,62 @@
+package gue
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// TestEnqueueSkipsNotifyByDefault checks that Enqueue never pays the extra
+// pg_notify round trip unless a caller opts in via WithClientNotify.
+func TestEnqueueSkipsNotifyByDefault(t *testing.T) {
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryRowFn: func(ctx context.Context, query string, args ...interface{}) adapter.Row {
+			return fakeRow{scan: func(dest ...interface{}) error {
+				*(dest[0].(*int64)) = 1
+				return nil
+			}}
+		},
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "pg_notify") {
+				t.Fatal("pg_notify should not be called without WithClientNotify")
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	c := NewClient(pool)
+	if err := c.Enqueue(context.Background(), NewJob("q1", "typeA", nil)); err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+}
+
+// TestEnqueueNotifiesWhenEnabled checks that WithClientNotify(true) makes
+// Enqueue issue a pg_notify after a successful insert.
+func TestEnqueueNotifiesWhenEnabled(t *testing.T) {
+	var notifyCalls int
+	pool := &fakeConnPool{fakeQueryable: fakeQueryable{
+		queryRowFn: func(ctx context.Context, query string, args ...interface{}) adapter.Row {
+			return fakeRow{scan: func(dest ...interface{}) error {
+				*(dest[0].(*int64)) = 1
+				return nil
+			}}
+		},
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "pg_notify") {
+				notifyCalls++
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	c := NewClient(pool, WithClientNotify(true))
+	if err := c.Enqueue(context.Background(), NewJob("q1", "typeA", nil)); err != nil {
+		t.Fatalf("Enqueue returned error: %v", err)
+	}
+
+	if notifyCalls != 1 {
+		t.Errorf("got %d pg_notify calls, want 1", notifyCalls)
+	}
+}
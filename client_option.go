@@ -0,0 +1,54 @@
+package gue
+
+import (
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// ClientOption defines a type that allows to set client properties during the build-time.
+type ClientOption func(*Client)
+
+// WithClientID sets client ID for easier identification in logs
+func WithClientID(id string) ClientOption {
+	return func(c *Client) {
+		c.id = id
+	}
+}
+
+// WithClientLogger sets Logger implementation to client
+func WithClientLogger(logger adapter.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithClientBackoff sets the default Backoff strategy used by jobs this
+// client locks.
+func WithClientBackoff(backoff Backoff) ClientOption {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithClientBackoffFor overrides the Backoff strategy used for jobs of the
+// given type. Jobs of every other type keep using the client's default
+// Backoff (see WithClientBackoff).
+func WithClientBackoffFor(jobType string, backoff Backoff) ClientOption {
+	return func(c *Client) {
+		if c.backoffs == nil {
+			c.backoffs = make(map[string]Backoff)
+		}
+		c.backoffs[jobType] = backoff
+	}
+}
+
+// WithClientNotify makes Enqueue/EnqueueTx/EnqueueBatch/EnqueueBatchTx
+// issue a pg_notify after inserting, waking any worker using
+// WithWorkerNotify/WithPoolNotify immediately instead of leaving it to
+// find the job on its next poll. It is off by default: notifying costs an
+// extra round trip per call (one per distinct queue for a batch), which
+// every producer would otherwise pay even if no worker is LISTENing.
+func WithClientNotify(notify bool) ClientOption {
+	return func(c *Client) {
+		c.notifyEnabled = notify
+	}
+}
@@ -0,0 +1,114 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// DeadJob is a snapshot of a job that was moved to the dead-letter queue,
+// either because its handler called Job.Dead or because its error count
+// reached the worker's maxErrorCount. DeadJob is read-only; use Client.RequeueDead to
+// put it back to work.
+type DeadJob struct {
+	ID         int64
+	Queue      string
+	Priority   int16
+	RunAt      time.Time
+	Type       string
+	Args       []byte
+	ErrorCount int32
+	LastError  sql.NullString
+	WorkerID   sql.NullString
+	CreatedAt  time.Time
+	DiedAt     time.Time
+}
+
+// ListDead returns up to limit dead jobs for the given queue, most recently
+// dead first. An empty queue lists dead jobs across all queues.
+func (c *Client) ListDead(ctx context.Context, queue string, limit int) ([]*DeadJob, error) {
+	query := `
+SELECT job_id, queue, priority, run_at, job_type, args, error_count, last_error, worker_id, created_at, died_at
+FROM gue_jobs_dead
+%s
+ORDER BY died_at DESC
+LIMIT $1`
+
+	args := []interface{}{limit}
+	var whereCond string
+	if queue != "" {
+		args = append(args, queue)
+		whereCond = fmt.Sprintf("WHERE queue = $%d", len(args))
+	}
+
+	rows, err := c.pool.Query(ctx, fmt.Sprintf(query, whereCond), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dead []*DeadJob
+	for rows.Next() {
+		d := DeadJob{}
+		if err := rows.Scan(
+			&d.ID, &d.Queue, &d.Priority, &d.RunAt, &d.Type, &d.Args,
+			&d.ErrorCount, &d.LastError, &d.WorkerID, &d.CreatedAt, &d.DiedAt,
+		); err != nil {
+			return nil, err
+		}
+		dead = append(dead, &d)
+	}
+
+	return dead, rows.Err()
+}
+
+// RequeueDead moves a job back from the dead-letter queue into gue_jobs so
+// workers pick it up again, resetting its error count and scheduling it to
+// run immediately.
+func (c *Client) RequeueDead(ctx context.Context, jobID int64) error {
+	tx, err := c.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, `
+INSERT INTO gue_jobs
+(job_id, queue, priority, run_at, job_type, args, error_count, created_at, updated_at)
+SELECT job_id, queue, priority, $2, job_type, args, 0, created_at, $2
+FROM gue_jobs_dead WHERE job_id = $1
+`, jobID, now)
+	if err != nil {
+		// nolint:errcheck
+		tx.Rollback(ctx)
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM gue_jobs_dead WHERE job_id = $1", jobID)
+	if err != nil {
+		// nolint:errcheck
+		tx.Rollback(ctx)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		// nolint:errcheck
+		tx.Rollback(ctx)
+		return adapter.ErrNoRows
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PurgeDead permanently deletes dead jobs that died before olderThan,
+// returning the number of jobs removed.
+func (c *Client) PurgeDead(ctx context.Context, olderThan time.Time) (int64, error) {
+	tag, err := c.pool.Exec(ctx, "DELETE FROM gue_jobs_dead WHERE died_at < $1", olderThan)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
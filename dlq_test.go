@@ -0,0 +1,72 @@
+package gue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// TestRequeueDeadRoundTrip checks that RequeueDead inserts the job back
+// into gue_jobs and removes it from gue_jobs_dead in the same transaction,
+// committing only when the dead row actually existed.
+func TestRequeueDeadRoundTrip(t *testing.T) {
+	var inserted, deleted bool
+	tx := &fakeTx{fakeQueryable: fakeQueryable{
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			switch {
+			case strings.Contains(query, "INSERT INTO gue_jobs"):
+				inserted = true
+				return fakeCommandTag{}, nil
+			case strings.Contains(query, "DELETE FROM gue_jobs_dead"):
+				deleted = true
+				return fakeCommandTag{rowsAffected: 1}, nil
+			default:
+				t.Fatalf("unexpected query: %s", query)
+				return fakeCommandTag{}, nil
+			}
+		},
+	}}
+	pool := &fakeConnPool{beginFn: func(context.Context) (adapter.Tx, error) { return tx, nil }}
+
+	c := NewClient(pool)
+	if err := c.RequeueDead(context.Background(), 42); err != nil {
+		t.Fatalf("RequeueDead returned error: %v", err)
+	}
+
+	if !inserted || !deleted {
+		t.Errorf("expected both an INSERT and a DELETE, got inserted=%v deleted=%v", inserted, deleted)
+	}
+	if !tx.committed {
+		t.Error("expected the transaction to be committed")
+	}
+}
+
+// TestRequeueDeadMissingJobRollsBack checks that requeueing a job that
+// isn't actually in the dead-letter queue rolls back instead of committing
+// a requeue insert with nothing to delete.
+func TestRequeueDeadMissingJobRollsBack(t *testing.T) {
+	tx := &fakeTx{fakeQueryable: fakeQueryable{
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "DELETE FROM gue_jobs_dead") {
+				return fakeCommandTag{rowsAffected: 0}, nil
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+	pool := &fakeConnPool{beginFn: func(context.Context) (adapter.Tx, error) { return tx, nil }}
+
+	c := NewClient(pool)
+	err := c.RequeueDead(context.Background(), 42)
+	if !errors.Is(err, adapter.ErrNoRows) {
+		t.Fatalf("RequeueDead error = %v, want adapter.ErrNoRows", err)
+	}
+	if tx.committed {
+		t.Error("expected the transaction to be rolled back, not committed")
+	}
+	if !tx.rolledBack {
+		t.Error("expected Rollback to be called")
+	}
+}
@@ -0,0 +1,112 @@
+package gue
+
+import (
+	"context"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// The fakes in this file are minimal adapter.* test doubles, scoped to this
+// package's tests. They implement exactly the methods this package calls
+// (Exec/Query/QueryRow/Scan/Next/Close/Err/Begin/Commit/Rollback/
+// RowsAffected), mirroring
+// the adapter interfaces as used throughout client.go/job.go/dlq.go.
+
+// fakeCommandTag is a minimal adapter.CommandTag test double.
+type fakeCommandTag struct{ rowsAffected int64 }
+
+func (t fakeCommandTag) RowsAffected() int64 { return t.rowsAffected }
+
+// fakeRow is a minimal adapter.Row test double driven by a scan callback.
+type fakeRow struct {
+	scan func(dest ...interface{}) error
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.scan == nil {
+		return adapter.ErrNoRows
+	}
+	return r.scan(dest...)
+}
+
+// fakeRows is a minimal adapter.Rows test double over an in-memory result
+// set, driven by a per-row scan callback.
+type fakeRows struct {
+	n      int
+	i      int
+	scanFn func(row int, dest ...interface{}) error
+}
+
+func (r *fakeRows) Next() bool {
+	if r.i >= r.n {
+		return false
+	}
+	r.i++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	return r.scanFn(r.i-1, dest...)
+}
+
+func (r *fakeRows) Close()     {}
+func (r *fakeRows) Err() error { return nil }
+
+// fakeQueryable is a minimal adapter.Queryable test double: every method
+// defers to an optional callback, falling back to an empty success result.
+type fakeQueryable struct {
+	execFn     func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error)
+	queryFn    func(ctx context.Context, query string, args ...interface{}) (adapter.Rows, error)
+	queryRowFn func(ctx context.Context, query string, args ...interface{}) adapter.Row
+}
+
+func (q *fakeQueryable) Exec(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+	if q.execFn != nil {
+		return q.execFn(ctx, query, args...)
+	}
+	return fakeCommandTag{}, nil
+}
+
+func (q *fakeQueryable) Query(ctx context.Context, query string, args ...interface{}) (adapter.Rows, error) {
+	if q.queryFn != nil {
+		return q.queryFn(ctx, query, args...)
+	}
+	return &fakeRows{}, nil
+}
+
+func (q *fakeQueryable) QueryRow(ctx context.Context, query string, args ...interface{}) adapter.Row {
+	if q.queryRowFn != nil {
+		return q.queryRowFn(ctx, query, args...)
+	}
+	return fakeRow{}
+}
+
+// fakeTx is a minimal adapter.Tx test double.
+type fakeTx struct {
+	fakeQueryable
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+// fakeConnPool is a minimal adapter.ConnPool test double.
+type fakeConnPool struct {
+	fakeQueryable
+	beginFn func(ctx context.Context) (adapter.Tx, error)
+}
+
+func (p *fakeConnPool) Begin(ctx context.Context) (adapter.Tx, error) {
+	if p.beginFn != nil {
+		return p.beginFn(ctx)
+	}
+	return &fakeTx{}, nil
+}
@@ -0,0 +1,175 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// Job is a single unit of work for Gue to perform.
+type Job struct {
+	// ID is the unique database ID of the Job. It is assigned by Gue once the
+	// Job has been enqueued.
+	ID int64
+
+	// Queue is the name of the queue the Job belongs to.
+	Queue string
+	// Priority is the priority of the Job. Lower values are worked first.
+	Priority int16
+	// RunAt is the earliest time the Job may be worked on.
+	RunAt time.Time
+	// Type maps job to a handler.
+	Type string
+	// Args is the JSON-encoded parameters to provide to the job handler.
+	Args []byte
+	// ErrorCount is the number of times this job has attempted to run and
+	// failed.
+	ErrorCount int32
+	// LastError is the error message or stack trace from the last time the
+	// job failed.
+	LastError sql.NullString
+
+	// Key is an optional, caller-supplied token used to deduplicate jobs.
+	// See WithUniqueKey.
+	Key string
+	// UniqueUntil is an optional hard expiry for Key: once it passes, a new
+	// Enqueue/EnqueueTx with the same Key deletes this job outright to make
+	// room for the new one, even if this job has never been worked. See
+	// WithUniqueUntil.
+	UniqueUntil time.Time
+
+	mu      sync.Mutex
+	deleted bool
+
+	pool          adapter.ConnPool
+	tx            adapter.Tx
+	backoff       Backoff
+	clientID      string
+	workerID      string
+	maxErrorCount int
+	lockedAt      time.Time
+}
+
+// Tx returns the transaction that this job is locked to. It may be used to
+// make updates to the database that are atomic with marking the job as
+// done or errored.
+func (j *Job) Tx() adapter.Tx {
+	return j.tx
+}
+
+// Done marks this job as complete by deleting it from the database. If
+// Done is not called, the job will be retried.
+//
+// After the job is marked as done, it is no longer safe to use it again.
+// Make sure to call Done before enqueueing more jobs within the same
+// transaction, as you cannot keep the transaction open indefinitely.
+func (j *Job) Done(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.deleted || j.tx == nil {
+		return nil
+	}
+	defer j.closeTx(ctx)
+
+	_, err := j.tx.Exec(ctx, "DELETE FROM gue_jobs WHERE job_id = $1", j.ID)
+	if err != nil {
+		return err
+	}
+
+	j.deleted = true
+	return nil
+}
+
+// Error marks the job as failed and schedules it to be retried using this
+// job's Backoff strategy. If the job has now failed maxErrorCount times or
+// more, it is moved to the dead-letter queue instead of being retried; see
+// Dead.
+func (j *Job) Error(ctx context.Context, jErr error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.deleted || j.tx == nil {
+		return nil
+	}
+	defer j.closeTx(ctx)
+
+	errorCount := j.ErrorCount + 1
+
+	if j.maxErrorCount > 0 && int(errorCount) >= j.maxErrorCount {
+		return j.markDead(ctx, jErr.Error())
+	}
+
+	var lastDuration time.Duration
+	if !j.lockedAt.IsZero() {
+		lastDuration = time.Since(j.lockedAt)
+	}
+	newRunAt := time.Now().Add(j.backoff.Duration(int(errorCount), jErr, lastDuration))
+
+	_, err := j.tx.Exec(ctx, `
+UPDATE gue_jobs
+SET error_count = $1, run_at = $2, last_error = $3, updated_at = now()
+WHERE job_id = $4`,
+		errorCount, newRunAt, jErr.Error(), j.ID)
+
+	return err
+}
+
+// Dead moves this job into the dead-letter queue with the given reason,
+// removing it from gue_jobs so workers stop selecting it. Use
+// Client.RequeueDead to give a dead job another chance, or
+// Client.ListDead/Client.PurgeDead to inspect and clean up the DLQ.
+func (j *Job) Dead(ctx context.Context, reason string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.deleted || j.tx == nil {
+		return nil
+	}
+	defer j.closeTx(ctx)
+
+	return j.markDead(ctx, reason)
+}
+
+// markDead does the work of Dead. Callers must hold j.mu and arrange to
+// call j.closeTx once done.
+func (j *Job) markDead(ctx context.Context, reason string) error {
+	workerID := j.workerID
+	if workerID == "" {
+		// This job was locked directly via Client.LockJob/LockJobMinError
+		// rather than through a Worker, so there's no worker id to record;
+		// fall back to the client id that locked it.
+		workerID = j.clientID
+	}
+
+	_, err := j.tx.Exec(ctx, `
+INSERT INTO gue_jobs_dead
+(job_id, queue, priority, run_at, job_type, args, error_count, last_error, worker_id, created_at)
+SELECT job_id, queue, priority, run_at, job_type, args, error_count + 1, $2, $3, created_at
+FROM gue_jobs WHERE job_id = $1
+`, j.ID, reason, workerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = j.tx.Exec(ctx, "DELETE FROM gue_jobs WHERE job_id = $1", j.ID)
+	if err != nil {
+		return err
+	}
+
+	j.deleted = true
+	return nil
+}
+
+func (j *Job) closeTx(ctx context.Context) {
+	if j.tx == nil {
+		return
+	}
+
+	// nolint:errcheck
+	j.tx.Commit(ctx)
+	j.tx = nil
+}
@@ -0,0 +1,49 @@
+package gue
+
+import "time"
+
+// JobOption defines a type that allows to set job properties during the build-time.
+type JobOption func(*Job)
+
+// NewJob creates a new Job ready to be passed to Client.Enqueue/EnqueueTx.
+func NewJob(queue, jobType string, args []byte, opts ...JobOption) *Job {
+	j := &Job{
+		Queue: queue,
+		Type:  jobType,
+		Args:  args,
+	}
+
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	return j
+}
+
+// WithUniqueKey sets a caller-supplied key that deduplicates this job: while
+// another job with the same key is still pending or running in the same
+// queue, Enqueue/EnqueueTx return ErrJobAlreadyExists instead of inserting
+// a second row.
+func WithUniqueKey(key string) JobOption {
+	return func(j *Job) {
+		j.Key = key
+	}
+}
+
+// WithUniqueUntil bounds how long this job's unique Key is held: once t has
+// passed, a later Enqueue/EnqueueTx with the same Key no longer sees
+// ErrJobAlreadyExists. Without this option the key is held for as long as
+// the job's row exists, i.e. until the job is worked and Done is called.
+//
+// This is a hard expiry, not just a release of the reservation: if this job
+// is still sitting in gue_jobs (never locked, or locked and still running)
+// when t passes, the next Enqueue/EnqueueTx call for the same Key deletes
+// this row outright to make room for the new one, so the original job is
+// discarded rather than worked. Only use WithUniqueUntil when a job that
+// never runs in time is fine to drop; otherwise leave it unset so the key
+// is held until the job actually completes.
+func WithUniqueUntil(t time.Time) JobOption {
+	return func(j *Job) {
+		j.UniqueUntil = t
+	}
+}
@@ -0,0 +1,111 @@
+package gue
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+func newLockedTestJob(tx *fakeTx, errorCount int32, maxErrorCount int) *Job {
+	return &Job{
+		ID:            1,
+		Queue:         "default",
+		Type:          "some-type",
+		ErrorCount:    errorCount,
+		tx:            tx,
+		pool:          &fakeConnPool{},
+		backoff:       ConstantBackoff(time.Millisecond),
+		maxErrorCount: maxErrorCount,
+		lockedAt:      time.Now(),
+	}
+}
+
+// TestJobErrorBelowThresholdRetries checks that Error() schedules a retry
+// (UPDATE gue_jobs) rather than moving the job to the DLQ while its error
+// count, after this failure, is still under maxErrorCount.
+func TestJobErrorBelowThresholdRetries(t *testing.T) {
+	var gotQuery string
+	tx := &fakeTx{fakeQueryable: fakeQueryable{
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			gotQuery = query
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	j := newLockedTestJob(tx, 1, 3) // errorCount becomes 2, below maxErrorCount 3
+	if err := j.Error(context.Background(), errors.New("boom")); err != nil {
+		t.Fatalf("Error returned error: %v", err)
+	}
+
+	if !strings.Contains(gotQuery, "UPDATE gue_jobs") {
+		t.Errorf("expected a retry UPDATE, got query: %s", gotQuery)
+	}
+	if !tx.committed {
+		t.Error("expected the transaction to be committed")
+	}
+}
+
+// TestJobErrorAtThresholdMovesToDeadLetterQueue checks that Error() moves
+// the job to the DLQ, instead of scheduling another retry, on the failure
+// that brings its error count up to maxErrorCount.
+func TestJobErrorAtThresholdMovesToDeadLetterQueue(t *testing.T) {
+	var gotQueries []string
+	tx := &fakeTx{fakeQueryable: fakeQueryable{
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			gotQueries = append(gotQueries, query)
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	j := newLockedTestJob(tx, 2, 3) // errorCount becomes 3, meets maxErrorCount 3
+	if err := j.Error(context.Background(), errors.New("boom")); err != nil {
+		t.Fatalf("Error returned error: %v", err)
+	}
+
+	if len(gotQueries) != 2 {
+		t.Fatalf("expected markDead's insert+delete, got %d queries: %v", len(gotQueries), gotQueries)
+	}
+	if !strings.Contains(gotQueries[0], "INSERT INTO gue_jobs_dead") {
+		t.Errorf("expected markDead's INSERT first, got: %s", gotQueries[0])
+	}
+	if !strings.Contains(gotQueries[1], "DELETE FROM gue_jobs") {
+		t.Errorf("expected markDead's DELETE second, got: %s", gotQueries[1])
+	}
+	if !j.deleted {
+		t.Error("expected job to be marked deleted after moving to the DLQ")
+	}
+	if !tx.committed {
+		t.Error("expected the transaction to be committed")
+	}
+}
+
+// TestJobMarkDeadUsesWorkerID checks that markDead records the locking
+// Worker's id, not the Client's id, so operators can tell which worker
+// poisoned a job.
+func TestJobMarkDeadUsesWorkerID(t *testing.T) {
+	var gotWorkerID string
+	tx := &fakeTx{fakeQueryable: fakeQueryable{
+		execFn: func(ctx context.Context, query string, args ...interface{}) (adapter.CommandTag, error) {
+			if strings.Contains(query, "INSERT INTO gue_jobs_dead") {
+				gotWorkerID = args[2].(string)
+			}
+			return fakeCommandTag{}, nil
+		},
+	}}
+
+	j := newLockedTestJob(tx, 0, 1)
+	j.clientID = "client-1"
+	j.workerID = "pool-1/worker-3"
+
+	if err := j.Error(context.Background(), errors.New("boom")); err != nil {
+		t.Fatalf("Error returned error: %v", err)
+	}
+
+	if gotWorkerID != "pool-1/worker-3" {
+		t.Errorf("markDead recorded worker_id = %q, want the worker id, not the client id", gotWorkerID)
+	}
+}
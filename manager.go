@@ -0,0 +1,42 @@
+package gue
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const defaultManagerFlushTimeout = 30 * time.Second
+
+// ManagerHandler returns an http.Handler that triggers a graceful
+// WorkerPool.Flush when invoked. Wire it up at an operator-only path (e.g.
+// POST /internal/flush-queues) so a deploy pipeline can drain the pool
+// before it is stopped, instead of letting in-flight jobs be killed mid
+// run. The response body is the FlushResult as JSON; a 200 means every
+// worker drained in time, a 202 means the timeout elapsed with jobs still
+// running.
+func ManagerHandler(pool *WorkerPool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		timeout := pool.shutdownTimeout
+		if timeout <= 0 {
+			timeout = defaultManagerFlushTimeout
+		}
+
+		result, err := pool.Flush(r.Context(), timeout)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(http.StatusAccepted)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		// nolint:errcheck
+		json.NewEncoder(w).Encode(result)
+	})
+}
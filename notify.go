@@ -0,0 +1,9 @@
+package gue
+
+// notifyChannel returns the LISTEN/NOTIFY channel name used for a queue.
+func notifyChannel(queue string) string {
+	if queue == "" {
+		queue = "default"
+	}
+	return "gue_jobs_" + queue
+}
@@ -0,0 +1,48 @@
+package gue
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// PauseQueue stops workers from locking any job in the given queue until
+// ResumeQueue is called, without requiring the workers themselves to be
+// stopped or scaled down. Use this to freeze a queue whose downstream
+// dependency is misbehaving.
+func (c *Client) PauseQueue(ctx context.Context, name, reason string) error {
+	_, err := c.pool.Exec(ctx, `
+INSERT INTO gue_queues (name, paused_at, paused_reason)
+VALUES ($1, now(), $2)
+ON CONFLICT (name) DO UPDATE SET paused_at = now(), paused_reason = $2
+`, name, reason)
+
+	return err
+}
+
+// ResumeQueue lets workers lock jobs from the given queue again.
+func (c *Client) ResumeQueue(ctx context.Context, name string) error {
+	_, err := c.pool.Exec(ctx, `
+INSERT INTO gue_queues (name, paused_at, paused_reason)
+VALUES ($1, NULL, NULL)
+ON CONFLICT (name) DO UPDATE SET paused_at = NULL, paused_reason = NULL
+`, name)
+
+	return err
+}
+
+// IsQueuePaused reports whether the given queue is currently paused.
+func (c *Client) IsQueuePaused(ctx context.Context, name string) (bool, error) {
+	var pausedAt sql.NullTime
+
+	err := c.pool.QueryRow(ctx, "SELECT paused_at FROM gue_queues WHERE name = $1", name).Scan(&pausedAt)
+	if err == adapter.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return pausedAt.Valid, nil
+}
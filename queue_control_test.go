@@ -0,0 +1,40 @@
+package gue
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// TestLockJobExcludesPausedQueues checks that LockJob's SELECT always
+// carries the NOT EXISTS guard against gue_queues, so a paused queue's jobs
+// are never locked even while other WHERE clauses (like maxErrorCount) vary.
+func TestLockJobExcludesPausedQueues(t *testing.T) {
+	for _, maxErrorCount := range []int{0, 5} {
+		var gotQuery string
+		tx := &fakeTx{fakeQueryable: fakeQueryable{
+			queryRowFn: func(ctx context.Context, query string, args ...interface{}) adapter.Row {
+				gotQuery = query
+				return fakeRow{}
+			},
+		}}
+		pool := &fakeConnPool{beginFn: func(context.Context) (adapter.Tx, error) { return tx, nil }}
+
+		c := NewClient(pool)
+		// fakeRow.Scan with no callback returns adapter.ErrNoRows, which
+		// LockJob treats as "no job found"; we only care about the query
+		// text that was sent, not the (absent) result.
+		if _, err := c.LockJob(context.Background(), "my-queue", maxErrorCount); err != nil {
+			t.Fatalf("LockJob returned error: %v", err)
+		}
+
+		if !strings.Contains(gotQuery, "FROM gue_queues") || !strings.Contains(gotQuery, "paused_at IS NOT NULL") {
+			t.Errorf("maxErrorCount=%d: LockJob query missing paused-queue guard: %s", maxErrorCount, gotQuery)
+		}
+		if !tx.rolledBack {
+			t.Error("expected LockJob to roll back its transaction when no job is found")
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package gue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+const defaultPollInterval = 5 * time.Second
+const defaultQueueName = ""
+
+// WorkFunc is a handler function that performs a Job. If the function
+// returns an error, the Job is retried according to its Backoff, or moved
+// to the dead-letter queue once it has failed the worker's maxErrorCount
+// times; call Job.Dead from within the handler to send a Job straight to
+// the dead-letter queue instead.
+type WorkFunc func(ctx context.Context, j *Job) error
+
+// WorkMap is a map of job types to WorkFuncs that are used to perform jobs
+// of a given type.
+type WorkMap map[string]WorkFunc
+
+// Worker is a single worker that pulls jobs off the given queue and
+// performs them continually.
+type Worker struct {
+	wm            WorkMap
+	interval      time.Duration
+	queue         string
+	c             *Client
+	id            string
+	logger        adapter.Logger
+	allJobs       bool
+	maxErrorCount int
+	minErrorCount int
+
+	pausePollInterval time.Duration
+	notify            bool
+
+	mu         sync.Mutex
+	currentJob *Job
+}
+
+// NewWorker returns a Worker that fetches Jobs from the Client and executes
+// them using WorkMap. If no WorkFunc is found for a job's type, the job is
+// deleted without being worked, and an error is logged unless
+// WithWorkerAllJobs is set.
+func NewWorker(c *Client, wm WorkMap, options ...WorkerOption) *Worker {
+	instance := Worker{
+		interval: defaultPollInterval,
+		queue:    defaultQueueName,
+		c:        c,
+		wm:       wm,
+		logger:   adapter.NoOpLogger{},
+	}
+
+	for _, option := range options {
+		option(&instance)
+	}
+
+	if instance.id == "" {
+		instance.id = newID()
+	}
+
+	instance.logger = instance.logger.With(adapter.F("worker-id", instance.id))
+
+	return &instance
+}
+
+// Run pulls jobs off the Worker's queue at its poll interval until the
+// context is done. If WithWorkerNotify is set and the Client's pool
+// supports LISTEN/NOTIFY, Run also wakes up as soon as a job is enqueued,
+// using the poll interval only as a safety net.
+func (w *Worker) Run(ctx context.Context) error {
+	return w.run(ctx, ctx.Done())
+}
+
+// run is Run, but stops polling for new work as soon as stop fires instead
+// of waiting for jobCtx to be done. jobCtx is still used to lock and work
+// jobs, so a WorkOne already in progress when stop fires always finishes
+// on a live context; jobCtx should only be cancelled once the caller has
+// given up waiting for that to happen. Run uses jobCtx.Done() as stop, so
+// standalone Workers keep their original cancel-to-stop behaviour.
+func (w *Worker) run(jobCtx context.Context, stop <-chan struct{}) error {
+	var notifyCh <-chan adapter.Notification
+	if w.notify {
+		if listener, ok := w.c.pool.(adapter.Listener); ok {
+			ch, err := listener.Listen(jobCtx, notifyChannel(w.queue))
+			if err != nil {
+				w.logger.Error("Worker failed to listen for notifications, falling back to polling only", adapter.Err(err))
+			} else {
+				notifyCh = ch
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stop:
+			return jobCtx.Err()
+		default:
+		}
+
+		if !w.WorkOne(jobCtx) {
+			interval := w.interval
+			if w.pausePollInterval > 0 {
+				if paused, err := w.c.IsQueuePaused(jobCtx, w.queue); err == nil && paused {
+					interval = w.pausePollInterval
+				}
+			}
+
+			select {
+			case <-stop:
+				return jobCtx.Err()
+			case _, ok := <-notifyCh:
+				if !ok {
+					// The listener gave up (e.g. its connection dropped)
+					// while we're still running: stop selecting on a
+					// closed channel, which would otherwise fire on every
+					// loop and busy-spin WorkOne with no backoff. Fall
+					// back to polling alone for the rest of this run.
+					notifyCh = nil
+					continue
+				}
+				// A job may be ready now; loop around immediately rather
+				// than waiting out the rest of the poll interval.
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// WorkOne locks and works exactly one job, returning true if it attempted
+// to work a job (whether or not the job succeeded) and false if there was
+// no job to work.
+func (w *Worker) WorkOne(ctx context.Context) (didWork bool) {
+	var j *Job
+	var err error
+	if w.minErrorCount > 0 {
+		j, err = w.c.LockJobMinError(ctx, w.queue, w.minErrorCount)
+	} else {
+		j, err = w.c.LockJob(ctx, w.queue, w.maxErrorCount)
+	}
+	if err != nil {
+		w.logger.Error("Worker failed to lock a job", adapter.Err(err))
+		return
+	}
+	if j == nil {
+		return
+	}
+
+	j.workerID = w.id
+
+	w.mu.Lock()
+	w.currentJob = j
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		w.currentJob = nil
+		w.mu.Unlock()
+
+		// nolint:errcheck
+		j.Done(ctx)
+	}()
+
+	didWork = true
+
+	wf, ok := w.wm[j.Type]
+	if !ok && !w.allJobs {
+		w.logger.Error("Got a job with unknown type", adapter.F("type", j.Type))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	if err := wf(ctx, j); err != nil {
+		// nolint:errcheck
+		j.Error(ctx, err)
+		return
+	}
+
+	return
+}
+
+// CurrentJob returns the job this worker is currently executing, or nil if
+// the worker is idle. It is primarily useful for reporting which jobs are
+// still in flight during a WorkerPool.Flush.
+func (w *Worker) CurrentJob() *Job {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.currentJob
+}
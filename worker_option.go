@@ -62,6 +62,28 @@ func WithWorkerMinErrorCount(count int) WorkerOption {
 	}
 }
 
+// WithWorkerNotify enables the LISTEN/NOTIFY fast path: the worker
+// subscribes to its queue's notify channel and wakes up as soon as a job
+// is enqueued, instead of waiting out its poll interval. Polling still
+// runs as a safety net for scheduled RunAt jobs and any notification
+// missed while busy. It is a no-op if the Client's pool doesn't implement
+// adapter.Listener.
+func WithWorkerNotify(notify bool) WorkerOption {
+	return func(w *Worker) {
+		w.notify = notify
+	}
+}
+
+// WithWorkerPausePollInterval overrides the poll interval the worker falls
+// back to while its queue is paused (see Client.PauseQueue), so a paused
+// worker backs off further than its normal poll interval instead of
+// polling a queue it knows yields nothing.
+func WithWorkerPausePollInterval(d time.Duration) WorkerOption {
+	return func(w *Worker) {
+		w.pausePollInterval = d
+	}
+}
+
 // WithPoolPollInterval overrides default poll interval with the given value.
 // Poll interval is the "sleep" duration if there were no jobs found in the DB.
 func WithPoolPollInterval(d time.Duration) WorkerPoolOption {
@@ -111,3 +133,31 @@ func WithWorkerPoolMinErrorCount(count int) WorkerPoolOption {
 		c.minErrorCount = count
 	}
 }
+
+// WithPoolNotify enables the LISTEN/NOTIFY fast path on every worker in the
+// pool; see WithWorkerNotify.
+func WithPoolNotify(notify bool) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.notify = notify
+	}
+}
+
+// WithWorkerPoolPausePollInterval overrides the poll interval workers in
+// the pool fall back to while their queue is paused; see
+// WithWorkerPausePollInterval.
+func WithWorkerPoolPausePollInterval(d time.Duration) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.pausePollInterval = d
+	}
+}
+
+// WithPoolShutdownTimeout caps how long WorkerPool.Shutdown will wait for
+// in-flight jobs to finish before giving up, regardless of the context
+// passed to it. Flush's own timeout argument takes precedence if it is
+// shorter. Unset (the default), Shutdown waits as long as its context
+// allows.
+func WithPoolShutdownTimeout(d time.Duration) WorkerPoolOption {
+	return func(w *WorkerPool) {
+		w.shutdownTimeout = d
+	}
+}
@@ -0,0 +1,180 @@
+package gue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vgarvardt/gue/v2/adapter"
+)
+
+// WorkerPool is a collection of Workers, each operating on the same queue
+// and WorkMap, that can process jobs in parallel.
+type WorkerPool struct {
+	wm                WorkMap
+	interval          time.Duration
+	queue             string
+	c                 *Client
+	id                string
+	logger            adapter.Logger
+	allJobs           bool
+	maxErrorCount     int
+	minErrorCount     int
+	pausePollInterval time.Duration
+	notify            bool
+	shutdownTimeout   time.Duration
+
+	workers []*Worker
+
+	mu         sync.Mutex
+	wg         sync.WaitGroup
+	stop       chan struct{}
+	stopOnce   *sync.Once
+	cancelJobs context.CancelFunc
+}
+
+// NewWorkerPool returns a WorkerPool with poolSize Workers, each pulling
+// from the given queue using the given Client.
+func NewWorkerPool(c *Client, wm WorkMap, poolSize int, options ...WorkerPoolOption) *WorkerPool {
+	instance := WorkerPool{
+		interval: defaultPollInterval,
+		queue:    defaultQueueName,
+		c:        c,
+		wm:       wm,
+		logger:   adapter.NoOpLogger{},
+	}
+
+	for _, option := range options {
+		option(&instance)
+	}
+
+	if instance.id == "" {
+		instance.id = newID()
+	}
+
+	instance.logger = instance.logger.With(adapter.F("worker-pool-id", instance.id))
+
+	instance.workers = make([]*Worker, poolSize)
+	for i := range instance.workers {
+		instance.workers[i] = NewWorker(
+			c, wm,
+			WithWorkerPollInterval(instance.interval),
+			WithWorkerQueue(instance.queue),
+			WithWorkerID(fmt.Sprintf("%s/worker-%d", instance.id, i)),
+			WithWorkerLogger(instance.logger),
+			WithWorkerAllJobs(instance.allJobs),
+			WithWorkerMaxErrorCount(instance.maxErrorCount),
+			WithWorkerMinErrorCount(instance.minErrorCount),
+			WithWorkerPausePollInterval(instance.pausePollInterval),
+			WithWorkerNotify(instance.notify),
+		)
+	}
+
+	return &instance
+}
+
+// Run starts every worker in the pool and blocks until the context is
+// done or Shutdown/Flush is called.
+func (w *WorkerPool) Run(ctx context.Context) error {
+	jobCtx, cancelJobs := context.WithCancel(ctx)
+	stop := make(chan struct{})
+
+	w.mu.Lock()
+	w.stop = stop
+	w.stopOnce = &sync.Once{}
+	w.cancelJobs = cancelJobs
+	w.mu.Unlock()
+	defer cancelJobs()
+
+	w.wg.Add(len(w.workers))
+	for _, worker := range w.workers {
+		go func(worker *Worker) {
+			defer w.wg.Done()
+
+			if err := worker.run(jobCtx, stop); err != nil && err != context.Canceled {
+				w.logger.Error("Worker exited with error", adapter.Err(err))
+			}
+		}(worker)
+	}
+
+	w.wg.Wait()
+	return nil
+}
+
+// Shutdown stops the pool from locking any new jobs and waits for every
+// in-flight job to reach Done()/Error(), leaving their context alone so
+// they get a real chance to finish instead of being aborted mid-handler.
+// It returns early with ctx's error if ctx is done (or this WorkerPool was
+// built with WithPoolShutdownTimeout and that much time elapses) before
+// every worker has gone idle; at that point, and only then, the in-flight
+// jobs' context is cancelled so they stop holding locks and connections.
+func (w *WorkerPool) Shutdown(ctx context.Context) error {
+	if w.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.shutdownTimeout)
+		defer cancel()
+	}
+
+	w.mu.Lock()
+	stop := w.stop
+	stopOnce := w.stopOnce
+	cancelJobs := w.cancelJobs
+	w.mu.Unlock()
+
+	if stop != nil {
+		stopOnce.Do(func() { close(stop) })
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		if cancelJobs != nil {
+			cancelJobs()
+		}
+		return ctx.Err()
+	}
+}
+
+// FlushJobInfo identifies a job that a worker was still executing when a
+// Flush timed out.
+type FlushJobInfo struct {
+	ID    int64
+	Queue string
+	Type  string
+}
+
+// FlushResult summarizes the outcome of a WorkerPool.Flush call.
+type FlushResult struct {
+	// StillRunning lists jobs that were still locked by a worker when the
+	// flush timeout elapsed. Empty means every worker drained in time.
+	StillRunning []FlushJobInfo
+}
+
+// Flush behaves like Shutdown, but waits up to timeout (instead of relying
+// solely on ctx or WithPoolShutdownTimeout) and reports which jobs, if
+// any, were still being worked once the timeout elapsed, instead of just
+// returning an error.
+func (w *WorkerPool) Flush(ctx context.Context, timeout time.Duration) (FlushResult, error) {
+	flushCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := w.Shutdown(flushCtx); err != nil {
+		var result FlushResult
+		for _, worker := range w.workers {
+			if j := worker.CurrentJob(); j != nil {
+				result.StillRunning = append(result.StillRunning, FlushJobInfo{ID: j.ID, Queue: j.Queue, Type: j.Type})
+			}
+		}
+		return result, err
+	}
+
+	return FlushResult{}, nil
+}